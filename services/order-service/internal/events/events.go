@@ -0,0 +1,87 @@
+// Package events defines the domain events published by the order service
+// and the envelope used to carry them across the message bus.
+package events
+
+import (
+	"strconv"
+	"time"
+)
+
+// Event type names published to the outbox / message bus.
+const (
+	TypeOrderCreated   = "OrderCreated"
+	TypeOrderConfirmed = "OrderConfirmed"
+	TypeOrderCancelled = "OrderCancelled"
+	TypeOrderFailed    = "OrderFailed"
+)
+
+// Envelope wraps a domain event payload with the metadata needed for
+// at-least-once delivery and distributed tracing across service boundaries.
+type Envelope struct {
+	// IdempotencyKey identifies one event version of an order and is the
+	// same on every retried publish of it, so consumers can dedupe them.
+	IdempotencyKey string            `json:"idempotency_key"`
+	Type           string            `json:"type"`
+	OrderID        string            `json:"order_id"`
+	Version        int               `json:"version"`
+	OccurredAt     time.Time         `json:"occurred_at"`
+	TraceHeaders   map[string]string `json:"trace_headers,omitempty"`
+	Payload        interface{}       `json:"payload"`
+}
+
+// IdempotencyKeyFor builds the outbox/consumer dedupe key for an event on a
+// given order, per the "order ID + event version" scheme.
+func IdempotencyKeyFor(orderID string, version int) string {
+	return orderID + ":" + strconv.Itoa(version)
+}
+
+// OrderCreatedPayload is published when a new order is persisted.
+type OrderCreatedPayload struct {
+	OrderID    string  `json:"order_id"`
+	UserID     string  `json:"user_id"`
+	ProductID  int     `json:"product_id"`
+	Quantity   int     `json:"quantity"`
+	TotalPrice float64 `json:"total_price"`
+}
+
+// OrderConfirmedPayload is published once the saga reserves stock and
+// collects payment for an order.
+type OrderConfirmedPayload struct {
+	OrderID string `json:"order_id"`
+}
+
+// OrderCancelledPayload is published when an order is cancelled by a client
+// via updateOrderStatus.
+type OrderCancelledPayload struct {
+	OrderID string `json:"order_id"`
+	Reason  string `json:"reason"`
+}
+
+// OrderFailedPayload is published when the saga compensates a pending or
+// reserved order because stock reservation failed.
+type OrderFailedPayload struct {
+	OrderID string `json:"order_id"`
+	Reason  string `json:"reason"`
+}
+
+// StockReservedPayload is consumed from the inventory service reply.
+type StockReservedPayload struct {
+	OrderID string `json:"order_id"`
+}
+
+// StockReservationFailedPayload is consumed from the inventory service reply.
+type StockReservationFailedPayload struct {
+	OrderID string `json:"order_id"`
+	Reason  string `json:"reason"`
+}
+
+// PaymentCompletedPayload is consumed from the payment service reply.
+type PaymentCompletedPayload struct {
+	OrderID string `json:"order_id"`
+}
+
+const (
+	TypeStockReserved          = "StockReserved"
+	TypeStockReservationFailed = "StockReservationFailed"
+	TypePaymentCompleted       = "PaymentCompleted"
+)
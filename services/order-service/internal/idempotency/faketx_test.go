@@ -0,0 +1,178 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fakeRecord is what the fake idempotency_keys table stores for a key_hash.
+type fakeRecord struct {
+	requestHash  string
+	responseBody []byte
+	statusCode   int64
+	expiresAt    time.Time
+}
+
+// fakeState is the in-memory backing store for the fake driver: just enough
+// of idempotency_keys to exercise Store/Lookup's conflict handling without a
+// real Postgres connection.
+type fakeState struct {
+	mu   sync.Mutex
+	rows map[string]fakeRecord
+}
+
+func newFakeState() *fakeState {
+	return &fakeState{rows: map[string]fakeRecord{}}
+}
+
+func (s *fakeState) query(query string, args []driver.Value) (driver.Rows, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.Contains(query, "INSERT INTO idempotency_keys"):
+		keyHash := args[0].(string)
+		expiresAt := toTime(args[5])
+		now := toTime(args[6])
+		if existing, exists := s.rows[keyHash]; exists && existing.expiresAt.After(now) {
+			// Mirrors the WHERE idempotency_keys.expires_at <= $7 clause on
+			// the real ON CONFLICT DO UPDATE: an unexpired row blocks reuse.
+			return &fakeRows{cols: []string{"key_hash"}}, nil
+		}
+		s.rows[keyHash] = fakeRecord{
+			requestHash:  args[1].(string),
+			responseBody: args[3].([]byte),
+			statusCode:   toInt64(args[4]),
+			expiresAt:    expiresAt,
+		}
+		return &fakeRows{cols: []string{"key_hash"}, row: []driver.Value{keyHash}, hasRow: true}, nil
+
+	case strings.Contains(query, "SELECT request_hash, response_body, status_code"):
+		keyHash := args[0].(string)
+		now := toTime(args[1])
+		rec, ok := s.rows[keyHash]
+		if !ok || !rec.expiresAt.After(now) {
+			return &fakeRows{cols: []string{"request_hash", "response_body", "status_code"}}, nil
+		}
+		return &fakeRows{
+			cols:   []string{"request_hash", "response_body", "status_code"},
+			row:    []driver.Value{rec.requestHash, rec.responseBody, rec.statusCode},
+			hasRow: true,
+		}, nil
+	}
+
+	return nil, errors.New("fakeState.query: unrecognized query: " + query)
+}
+
+func (s *fakeState) exec(query string, args []driver.Value) (driver.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.Contains(query, "DELETE FROM idempotency_keys") {
+		n := int64(len(s.rows))
+		s.rows = map[string]fakeRecord{}
+		return fakeResult{rows: n}, nil
+	}
+
+	return nil, errors.New("fakeState.exec: unrecognized query: " + query)
+}
+
+func toTime(v driver.Value) time.Time {
+	t, ok := v.(time.Time)
+	if !ok {
+		panic("toTime: unsupported type")
+	}
+	return t
+}
+
+func toInt64(v driver.Value) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	case int:
+		return int64(n)
+	default:
+		panic("toInt64: unsupported type")
+	}
+}
+
+type fakeResult struct{ rows int64 }
+
+func (r fakeResult) LastInsertId() (int64, error) { return 0, nil }
+func (r fakeResult) RowsAffected() (int64, error) { return r.rows, nil }
+
+// fakeRows implements driver.Rows for a zero-or-one-row result, which is all
+// QueryRowContext ever needs here.
+type fakeRows struct {
+	cols   []string
+	row    []driver.Value
+	hasRow bool
+	served bool
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if !r.hasRow || r.served {
+		return io.EOF
+	}
+	r.served = true
+	copy(dest, r.row)
+	return nil
+}
+
+// fakeDriver / fakeConn adapt fakeState to database/sql, so a *sql.DB backed
+// by it can be passed to Lookup/Store exactly like the real thing.
+type fakeDriver struct{ state *fakeState }
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{state: d.state}, nil
+}
+
+type fakeConn struct{ state *fakeState }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fakeConn: Prepare not supported, use ExecContext/QueryContext")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeDriverTx{}, nil }
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return fakeDriverTx{}, nil
+}
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.state.exec(query, namedValues(args))
+}
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.state.query(query, namedValues(args))
+}
+
+func namedValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	return values
+}
+
+type fakeDriverTx struct{}
+
+func (fakeDriverTx) Commit() error   { return nil }
+func (fakeDriverTx) Rollback() error { return nil }
+
+// newFakeDB registers a fresh driver under name and opens a *sql.DB backed
+// by state. name must be unique per test (e.g. t.Name()) since database/sql
+// panics on a duplicate driver registration.
+func newFakeDB(name string, state *fakeState) *sql.DB {
+	sql.Register(name, &fakeDriver{state: state})
+	db, err := sql.Open(name, "")
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
@@ -0,0 +1,168 @@
+// Package idempotency lets createOrder safely replay the response to a
+// retried POST /orders instead of creating a duplicate order. A client
+// scopes a retry with an Idempotency-Key header; the key plus the caller's
+// user ID identify the stored record, and a hash of the full request body
+// detects a client reusing the same key for a genuinely different request.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// DefaultTTL is how long a stored response is replayed before the key is
+// free to be reused for a new request.
+const DefaultTTL = 24 * time.Hour
+
+// CreateTableSQL creates the idempotency_keys table if it does not already
+// exist. key_hash identifies the (Idempotency-Key, user) pair a record
+// belongs to; request_hash additionally fingerprints the request body, so a
+// key reused with a different body can be told apart from a genuine retry.
+const CreateTableSQL = `
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+	key_hash CHAR(64) PRIMARY KEY,
+	request_hash CHAR(64) NOT NULL,
+	order_id VARCHAR(36) NOT NULL,
+	response_body JSONB NOT NULL,
+	status_code INT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at TIMESTAMP NOT NULL
+);`
+
+// Hash returns the hex-encoded sha256 of parts concatenated in order.
+func Hash(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Record is a previously stored response for a key_hash.
+type Record struct {
+	RequestHash  string
+	ResponseBody json.RawMessage
+	StatusCode   int
+}
+
+// querier is satisfied by *bun.DB and *sql.DB, so Lookup can run against
+// either without this package depending on bun.
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Lookup returns the unexpired record stored for keyHash, or nil if there
+// isn't one.
+func Lookup(ctx context.Context, q querier, keyHash string) (*Record, error) {
+	const selectSQL = `
+		SELECT request_hash, response_body, status_code
+		FROM idempotency_keys
+		WHERE key_hash = $1 AND expires_at > $2`
+
+	var rec Record
+	var body []byte
+	err := q.QueryRowContext(ctx, selectSQL, keyHash, time.Now()).Scan(&rec.RequestHash, &body, &rec.StatusCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rec.ResponseBody = body
+	return &rec, nil
+}
+
+// txQuerier is satisfied by both *sql.Tx and bun.Tx, so Store can be called
+// from either the raw database/sql path or a bun transaction without this
+// package depending on bun.
+type txQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// ErrConflict is returned by Store when keyHash was already recorded by
+// another transaction. Two concurrent copies of the same retried request can
+// both reach Store having seen no prior Lookup result; ErrConflict lets the
+// loser roll back its own order insert instead of committing a duplicate
+// order alongside a Store call that silently no-ops.
+var ErrConflict = errors.New("idempotency key already recorded")
+
+// Store records the response produced for keyHash as part of tx, so the row
+// is only durable if the order it accompanies committed. It returns
+// ErrConflict, without writing anything, if keyHash was already recorded by
+// an unexpired row - callers must treat that as a signal to roll back the
+// rest of tx and look up the winning record to replay instead. A row whose
+// TTL has already elapsed is overwritten rather than treated as a conflict,
+// so a key can be legitimately reused once expired without waiting for the
+// Janitor to have swept it first.
+func Store(ctx context.Context, tx txQuerier, keyHash, requestHash, orderID string, responseBody []byte, statusCode int, ttl time.Duration) error {
+	const insertSQL = `
+		INSERT INTO idempotency_keys (key_hash, request_hash, order_id, response_body, status_code, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (key_hash) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			order_id = EXCLUDED.order_id,
+			response_body = EXCLUDED.response_body,
+			status_code = EXCLUDED.status_code,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= $7
+		RETURNING key_hash`
+
+	now := time.Now()
+	var inserted string
+	err := tx.QueryRowContext(ctx, insertSQL, keyHash, requestHash, orderID, responseBody, statusCode, now.Add(ttl), now).Scan(&inserted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrConflict
+	}
+	return err
+}
+
+// Janitor periodically deletes expired idempotency_keys rows so the table
+// doesn't grow unbounded and an expired key becomes reusable.
+type Janitor struct {
+	db       *sql.DB
+	interval time.Duration
+}
+
+// NewJanitor creates a Janitor that sweeps db's idempotency_keys table every
+// interval.
+func NewJanitor(db *sql.DB, interval time.Duration) *Janitor {
+	return &Janitor{db: db, interval: interval}
+}
+
+// Run sweeps until ctx is cancelled. It is meant to be started in its own
+// goroutine from main.
+func (j *Janitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.sweepOnce(ctx); err != nil {
+				logSweepError(err)
+			}
+		}
+	}
+}
+
+func (j *Janitor) sweepOnce(ctx context.Context) error {
+	const deleteSQL = `DELETE FROM idempotency_keys WHERE expires_at <= $1`
+	_, err := j.db.ExecContext(ctx, deleteSQL, time.Now())
+	return err
+}
+
+// logSweepError is a narrow seam so the janitor doesn't pull in logrus
+// directly; main wires it to the service logger.
+var logSweepError = func(err error) {}
+
+// SetErrorLogger overrides how Janitor reports sweep failures.
+func SetErrorLogger(f func(error)) {
+	logSweepError = f
+}
@@ -0,0 +1,122 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  []string
+		equal bool
+	}{
+		{
+			name:  "identical inputs hash the same",
+			a:     []string{"key-1", "user-1"},
+			b:     []string{"key-1", "user-1"},
+			equal: true,
+		},
+		{
+			name:  "different user, same key, differ",
+			a:     []string{"key-1", "user-1"},
+			b:     []string{"key-1", "user-2"},
+			equal: false,
+		},
+		{
+			name:  "part order matters",
+			a:     []string{"a", "b"},
+			b:     []string{"b", "a"},
+			equal: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Hash(tt.a...) == Hash(tt.b...)
+			if got != tt.equal {
+				t.Fatalf("Hash(%v) == Hash(%v) = %v, want %v", tt.a, tt.b, got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestStoreDetectsConcurrentConflict(t *testing.T) {
+	db := newFakeDB(t.Name(), newFakeState())
+	ctx := context.Background()
+
+	// The winner of a race between two copies of the same retried request.
+	if err := Store(ctx, db, "key-hash", "req-hash", "order-1", []byte(`{"id":"order-1"}`), 201, time.Hour); err != nil {
+		t.Fatalf("first Store() = %v, want nil", err)
+	}
+
+	// The loser: same key_hash, arriving after the winner already committed.
+	err := Store(ctx, db, "key-hash", "req-hash", "order-2", []byte(`{"id":"order-2"}`), 201, time.Hour)
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("second Store() = %v, want ErrConflict", err)
+	}
+}
+
+func TestStoreThenLookupReplaysWinningRecord(t *testing.T) {
+	db := newFakeDB(t.Name(), newFakeState())
+	ctx := context.Background()
+
+	responseBody := []byte(`{"id":"order-1"}`)
+	if err := Store(ctx, db, "key-hash", "req-hash", "order-1", responseBody, 201, time.Hour); err != nil {
+		t.Fatalf("Store() = %v, want nil", err)
+	}
+
+	rec, err := Lookup(ctx, db, "key-hash")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if rec == nil {
+		t.Fatal("Lookup() = nil, want the stored record")
+	}
+	if rec.RequestHash != "req-hash" || rec.StatusCode != 201 || string(rec.ResponseBody) != string(responseBody) {
+		t.Fatalf("Lookup() = %+v, want request_hash=req-hash status=201 body=%s", rec, responseBody)
+	}
+}
+
+func TestStoreReusesKeyAfterExpiry(t *testing.T) {
+	db := newFakeDB(t.Name(), newFakeState())
+	ctx := context.Background()
+
+	// A row whose TTL has already elapsed - as if the client's original
+	// request happened long ago and the hourly Janitor hasn't swept it yet.
+	if err := Store(ctx, db, "key-hash", "req-hash-1", "order-1", []byte(`{"id":"order-1"}`), 201, -time.Hour); err != nil {
+		t.Fatalf("Store() (expired) = %v, want nil", err)
+	}
+
+	// A legitimate reuse of the same Idempotency-Key for a new request
+	// should succeed rather than being treated as a live conflict.
+	newBody := []byte(`{"id":"order-2"}`)
+	if err := Store(ctx, db, "key-hash", "req-hash-2", "order-2", newBody, 201, time.Hour); err != nil {
+		t.Fatalf("Store() (reuse after expiry) = %v, want nil", err)
+	}
+
+	rec, err := Lookup(ctx, db, "key-hash")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if rec == nil {
+		t.Fatal("Lookup() = nil, want the record written by the reuse")
+	}
+	if rec.RequestHash != "req-hash-2" || string(rec.ResponseBody) != string(newBody) {
+		t.Fatalf("Lookup() = %+v, want the reused row's data (request_hash=req-hash-2 body=%s)", rec, newBody)
+	}
+}
+
+func TestLookupMissingKeyReturnsNil(t *testing.T) {
+	db := newFakeDB(t.Name(), newFakeState())
+
+	rec, err := Lookup(context.Background(), db, "no-such-key")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if rec != nil {
+		t.Fatalf("Lookup() = %+v, want nil for an unrecorded key", rec)
+	}
+}
@@ -0,0 +1,82 @@
+// Package loghook wires logrus log lines to the active OpenTelemetry trace
+// so an operator can jump from a log line in Loki/ELK straight to the
+// matching span in Jaeger, and gives Gin handlers a request-scoped
+// *logrus.Entry to log through instead of the package-level logger.
+package loghook
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// entryKey is the gin.Context key under which Middleware stores the
+// request-scoped *logrus.Entry.
+const entryKey = "loghook.entry"
+
+// TraceCorrelator is a logrus.Hook that stamps trace_id, span_id, and
+// trace_flags onto any log entry whose context carries a valid span.
+// Entries logged without a context, or outside of a span, are left
+// untouched.
+type TraceCorrelator struct{}
+
+// NewTraceCorrelator returns a TraceCorrelator ready to be registered with
+// logrus.AddHook.
+func NewTraceCorrelator() *TraceCorrelator {
+	return &TraceCorrelator{}
+}
+
+// Levels implements logrus.Hook and applies the correlator to every level.
+func (h *TraceCorrelator) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (h *TraceCorrelator) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+
+	sc := trace.SpanContextFromContext(entry.Context)
+	if !sc.IsValid() {
+		return nil
+	}
+
+	entry.Data["trace_id"] = sc.TraceID().String()
+	entry.Data["span_id"] = sc.SpanID().String()
+	entry.Data["trace_flags"] = sc.TraceFlags().String()
+	return nil
+}
+
+// Middleware returns a gin.HandlerFunc that builds a *logrus.Entry carrying
+// the request's context, http.method, http.route, a generated request_id,
+// and user.id when it's available as a query parameter, then stores it on
+// the gin.Context for handlers to retrieve via Entry.
+func Middleware(logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fields := logrus.Fields{
+			"http.method": c.Request.Method,
+			"http.route":  c.FullPath(),
+			"request_id":  uuid.New().String(),
+		}
+		if userID := c.Query("user_id"); userID != "" {
+			fields["user.id"] = userID
+		}
+
+		entry := logger.WithContext(c.Request.Context()).WithFields(fields)
+		c.Set(entryKey, entry)
+		c.Next()
+	}
+}
+
+// Entry returns the request-scoped *logrus.Entry stored by Middleware, so
+// handlers can log through it and pick up the trace correlation and request
+// fields it carries. It falls back to the standard logger bound to the
+// request context if Middleware wasn't installed.
+func Entry(c *gin.Context) *logrus.Entry {
+	if v, ok := c.Get(entryKey); ok {
+		return v.(*logrus.Entry)
+	}
+	return logrus.WithContext(c.Request.Context())
+}
@@ -0,0 +1,41 @@
+// Package messagebus abstracts the broker used to move domain events between
+// the order service and the rest of the saga participants (inventory,
+// payment). The concrete implementation is NATS JetStream, so a published
+// message is durably stored and redelivered until acked rather than dropped
+// when nobody's currently subscribed; callers depend only on the
+// Publisher/Subscriber interfaces so a Kafka or RabbitMQ implementation can
+// be swapped in later without touching the outbox or saga packages.
+package messagebus
+
+import "context"
+
+// Publisher sends a message onto subject. Implementations are expected to
+// propagate the W3C trace context carried on ctx as message headers so
+// consumers can continue the caller's trace.
+type Publisher interface {
+	Publish(ctx context.Context, subject string, v interface{}) error
+}
+
+// Handler processes one delivered message. The ctx passed to it carries the
+// trace context extracted from the message headers, if any.
+type Handler func(ctx context.Context, data []byte) error
+
+// Subscriber consumes messages published to subject, invoking h for each
+// one. Subscribe returns once the subscription is registered; delivery
+// happens on background goroutines until the returned Subscription is
+// unsubscribed. ctx is the parent context of every Handler call; cancelling
+// it does not end the subscription.
+type Subscriber interface {
+	Subscribe(ctx context.Context, subject string, h Handler) (Subscription, error)
+}
+
+// Subscription represents an active subscription that can be torn down.
+type Subscription interface {
+	Unsubscribe() error
+}
+
+// Bus combines Publisher and Subscriber, which is what most callers need.
+type Bus interface {
+	Publisher
+	Subscriber
+}
@@ -0,0 +1,142 @@
+package messagebus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// streamName is the JetStream stream backing every subject this package
+// publishes to. Publish and Subscribe route through it instead of plain
+// NATS core pub/sub so a message survives a broker restart and isn't
+// dropped when no subscriber is currently connected.
+const streamName = "ORDERS"
+
+// NATSBus is the Bus implementation backed by a NATS JetStream stream.
+type NATSBus struct {
+	conn *nats.Conn
+	js   nats.JetStreamContext
+	prop propagation.TextMapPropagator
+}
+
+// NewNATSBus connects to url, ensures the backing JetStream stream exists,
+// and returns a ready-to-use Bus. Callers own the returned connection's
+// lifecycle via Close.
+func NewNATSBus(url string) (*NATSBus, error) {
+	conn, err := nats.Connect(url, nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if _, err := js.AddStream(&nats.StreamConfig{
+		Name:     streamName,
+		Subjects: []string{"orders.>"},
+	}); err != nil && !errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		conn.Close()
+		return nil, fmt.Errorf("ensure %s stream: %w", streamName, err)
+	}
+
+	return &NATSBus{conn: conn, js: js, prop: propagation.TraceContext{}}, nil
+}
+
+// Close drains and closes the underlying NATS connection: in-flight
+// subscription handlers get to finish and ack/nak before the connection
+// actually closes, rather than being cut off mid-message.
+func (b *NATSBus) Close() {
+	if err := b.conn.Drain(); err != nil {
+		logHandlerError(err)
+	}
+}
+
+// Publish implements Publisher. It publishes onto the JetStream stream and
+// waits for the broker to durably persist the message before returning, so
+// a nil error means the event survives even if no consumer is currently
+// subscribed - unlike NATS core pub/sub, which hands the message to the
+// broker and drops it if nothing is listening. The W3C trace context on ctx
+// is injected as NATS message headers so Subscribe can continue the trace
+// on the consumer side.
+func (b *NATSBus) Publish(ctx context.Context, subject string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	headers := nats.Header{}
+	carrier := propagation.MapCarrier{}
+	b.prop.Inject(ctx, carrier)
+	for k, v := range carrier {
+		headers.Set(k, v)
+	}
+
+	_, err = b.js.PublishMsg(&nats.Msg{
+		Subject: subject,
+		Data:    data,
+		Header:  headers,
+	})
+	return err
+}
+
+// Subscribe implements Subscriber using a durable JetStream queue consumer,
+// so multiple order-service instances share the workload for a subject and
+// a restarted or newly deployed instance resumes from where the consumer
+// left off instead of missing whatever was published while nothing was
+// subscribed. Acking is manual: a message is only acked once h returns nil,
+// and a returned error both gets logged (see SetErrorLogger) and Naks the
+// message so JetStream redelivers it, rather than the error being silently
+// discarded.
+func (b *NATSBus) Subscribe(ctx context.Context, subject string, h Handler) (Subscription, error) {
+	durable := durableName(subject)
+	sub, err := b.js.QueueSubscribe(subject, durable, func(msg *nats.Msg) {
+		msgCtx := ctx
+		if msg.Header != nil {
+			carrier := propagation.MapCarrier{}
+			for k := range msg.Header {
+				carrier.Set(k, msg.Header.Get(k))
+			}
+			msgCtx = b.prop.Extract(ctx, carrier)
+		}
+
+		if err := h(msgCtx, msg.Data); err != nil {
+			logHandlerError(err)
+			if nakErr := msg.Nak(); nakErr != nil {
+				logHandlerError(nakErr)
+			}
+			return
+		}
+		if err := msg.Ack(); err != nil {
+			logHandlerError(err)
+		}
+	}, nats.Durable(durable), nats.ManualAck(), nats.AckExplicit())
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// durableName derives a JetStream durable consumer name from subject.
+// Durable names can't contain '.', so orders.StockReserved becomes
+// orders_StockReserved_workers.
+func durableName(subject string) string {
+	return strings.ReplaceAll(subject, ".", "_") + "_workers"
+}
+
+// logHandlerError is a narrow seam so this package doesn't pull in logrus
+// directly; main wires it to the service logger.
+var logHandlerError = func(err error) {}
+
+// SetErrorLogger overrides how Subscribe reports handler and ack/nak
+// failures.
+func SetErrorLogger(f func(error)) {
+	logHandlerError = f
+}
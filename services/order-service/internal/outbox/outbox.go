@@ -0,0 +1,185 @@
+// Package outbox implements the transactional outbox pattern for the order
+// service: domain events are written to an `outbox` table inside the same
+// SQL transaction as the order mutation that produced them, and a background
+// Publisher drains the table with at-least-once delivery.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+
+	"order-service/internal/events"
+	"order-service/internal/messagebus"
+)
+
+// CreateTableSQL creates the outbox table if it does not already exist.
+// The idempotency key is unique so a retried Write is a no-op rather than a
+// duplicate row.
+const CreateTableSQL = `
+CREATE TABLE IF NOT EXISTS outbox (
+	id BIGSERIAL PRIMARY KEY,
+	idempotency_key VARCHAR(128) NOT NULL UNIQUE,
+	order_id VARCHAR(36) NOT NULL,
+	event_type VARCHAR(64) NOT NULL,
+	payload JSONB NOT NULL,
+	trace_headers JSONB,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	published_at TIMESTAMP
+);`
+
+// execer is satisfied by both *sql.Tx and bun.Tx, so Write can be called
+// from either the raw database/sql path or a bun transaction without this
+// package depending on bun.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Write inserts a domain event into the outbox as part of tx. Callers must
+// run this in the same transaction as the order insert/update it accompanies
+// so the event is durable if and only if the order change committed.
+func Write(ctx context.Context, tx execer, orderID, eventType string, version int, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{}
+	propagation.TraceContext{}.Inject(ctx, propagation.MapCarrier(headers))
+	headersJSON, err := json.Marshal(headers)
+	if err != nil {
+		return err
+	}
+
+	const insertSQL = `
+		INSERT INTO outbox (idempotency_key, order_id, event_type, payload, trace_headers)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (idempotency_key) DO NOTHING`
+
+	_, err = tx.ExecContext(ctx, insertSQL,
+		events.IdempotencyKeyFor(orderID, version), orderID, eventType, payloadJSON, headersJSON)
+	return err
+}
+
+// Publisher polls the outbox table and forwards unpublished rows to a
+// messagebus.Publisher, marking them published on success. It provides
+// at-least-once delivery: a row is only marked published after the broker
+// acknowledges it, so a crash between publish and mark-published results in
+// a redelivery rather than a loss.
+type Publisher struct {
+	db       *sql.DB
+	bus      messagebus.Publisher
+	interval time.Duration
+	batch    int
+}
+
+// NewPublisher creates a Publisher that drains db's outbox table onto bus
+// every interval, up to batch rows per poll.
+func NewPublisher(db *sql.DB, bus messagebus.Publisher, interval time.Duration, batch int) *Publisher {
+	return &Publisher{db: db, bus: bus, interval: interval, batch: batch}
+}
+
+// Run polls until ctx is cancelled. It is meant to be started in its own
+// goroutine from main.
+func (p *Publisher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.drainOnce(ctx); err != nil {
+				logDrainError(err)
+			}
+		}
+	}
+}
+
+type outboxRow struct {
+	id             int64
+	idempotencyKey string
+	orderID        string
+	eventType      string
+	payload        json.RawMessage
+	traceHeaders   sql.NullString
+}
+
+func (p *Publisher) drainOnce(ctx context.Context) error {
+	const selectSQL = `
+		SELECT id, idempotency_key, order_id, event_type, payload, trace_headers
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id ASC
+		LIMIT $1`
+
+	rows, err := p.db.QueryContext(ctx, selectSQL, p.batch)
+	if err != nil {
+		return err
+	}
+
+	var pending []outboxRow
+	for rows.Next() {
+		var r outboxRow
+		if err := rows.Scan(&r.id, &r.idempotencyKey, &r.orderID, &r.eventType, &r.payload, &r.traceHeaders); err != nil {
+			rows.Close()
+			return err
+		}
+		pending = append(pending, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, r := range pending {
+		if err := p.publishRow(ctx, r); err != nil {
+			logDrainError(err)
+			continue
+		}
+	}
+	return nil
+}
+
+func (p *Publisher) publishRow(ctx context.Context, r outboxRow) error {
+	pubCtx := ctx
+	if r.traceHeaders.Valid {
+		headers := map[string]string{}
+		if err := json.Unmarshal([]byte(r.traceHeaders.String), &headers); err == nil {
+			pubCtx = propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier(headers))
+		}
+	}
+
+	env := events.Envelope{
+		IdempotencyKey: r.idempotencyKey,
+		Type:           r.eventType,
+		OrderID:        r.orderID,
+		OccurredAt:     time.Now(),
+		Payload:        json.RawMessage(r.payload),
+	}
+
+	if err := p.bus.Publish(pubCtx, subjectFor(r.eventType), env); err != nil {
+		return err
+	}
+
+	const markSQL = `UPDATE outbox SET published_at = $1 WHERE id = $2`
+	_, err := p.db.ExecContext(ctx, markSQL, time.Now(), r.id)
+	return err
+}
+
+func subjectFor(eventType string) string {
+	return "orders." + eventType
+}
+
+// logDrainError is a narrow seam so the publisher doesn't pull in logrus
+// directly; main wires it to the service logger.
+var logDrainError = func(err error) {}
+
+// SetErrorLogger overrides how Publisher reports per-row drain failures.
+func SetErrorLogger(f func(error)) {
+	logDrainError = f
+}
@@ -0,0 +1,61 @@
+package productclient
+
+import (
+	"context"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"order-service/internal/productclient/productcatalogpb"
+)
+
+// grpcClient talks to the product service's ProductCatalog gRPC service,
+// the same shape as the OpenTelemetry demo's productcatalogservice.
+type grpcClient struct {
+	conn   *grpc.ClientConn
+	client productcatalogpb.ProductCatalogClient
+}
+
+func newGRPCClient(addr string) (*grpcClient, error) {
+	conn, err := grpc.Dial(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcClient{conn: conn, client: productcatalogpb.NewProductCatalogClient(conn)}, nil
+}
+
+func (c *grpcClient) GetProduct(ctx context.Context, productID int) (*Product, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("product.id", productID))
+
+	resp, err := c.client.GetProduct(ctx, &productcatalogpb.GetProductRequest{Id: int32(productID)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	product := &Product{
+		ID:            int(resp.GetId()),
+		Name:          resp.GetName(),
+		Price:         resp.GetPrice(),
+		StockQuantity: int(resp.GetStockQuantity()),
+	}
+
+	span.SetAttributes(
+		attribute.String("product.name", product.Name),
+		attribute.Float64("product.price", product.Price),
+		attribute.Int("product.stock", product.StockQuantity),
+	)
+
+	return product, nil
+}
@@ -0,0 +1,229 @@
+// Package productclient talks to the product service on behalf of
+// createOrder. It wraps either a REST or gRPC transport with a circuit
+// breaker and bounded, jittered retries so a slow or unhealthy product
+// service degrades order-service gracefully instead of cascading.
+package productclient
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Product is the product data order-service needs to price and validate an
+// order.
+type Product struct {
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	Price         float64 `json:"price"`
+	StockQuantity int     `json:"stock_quantity"`
+}
+
+// ErrNotFound is returned by a transport when the product service reports
+// the product doesn't exist (REST 404, gRPC codes.NotFound). It is a client
+// error, not an outage: callWithRetry doesn't retry it and the circuit
+// breaker doesn't count it as a failure, so a burst of lookups for
+// nonexistent IDs can't trip the breaker for everyone else.
+var ErrNotFound = errors.New("product not found")
+
+// Client fetches a Product by ID.
+type Client interface {
+	GetProduct(ctx context.Context, productID int) (*Product, error)
+}
+
+// breaker states as the values recorded on the product_client_breaker_state
+// gauge.
+const (
+	stateClosed   = 0
+	stateHalfOpen = 1
+	stateOpen     = 2
+)
+
+const maxAttempts = 3
+
+// Config configures the resilient Client.
+type Config struct {
+	// Protocol selects the underlying transport: "http" (default) or "grpc".
+	Protocol string
+	// BaseURL is the REST product service base URL (http protocol).
+	BaseURL string
+	// Addr is the gRPC product service address (grpc protocol).
+	Addr string
+	// Timeout bounds a single call attempt.
+	Timeout time.Duration
+}
+
+// ConfigFromEnv reads PRODUCT_SERVICE_PROTOCOL, PRODUCT_SERVICE_URL,
+// PRODUCT_SERVICE_ADDR, and PRODUCT_CLIENT_TIMEOUT.
+func ConfigFromEnv() Config {
+	cfg := Config{
+		Protocol: os.Getenv("PRODUCT_SERVICE_PROTOCOL"),
+		BaseURL:  os.Getenv("PRODUCT_SERVICE_URL"),
+		Addr:     os.Getenv("PRODUCT_SERVICE_ADDR"),
+		Timeout:  2 * time.Second,
+	}
+	if cfg.Protocol == "" {
+		cfg.Protocol = "http"
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "http://localhost:5000"
+	}
+	if cfg.Addr == "" {
+		cfg.Addr = "localhost:9000"
+	}
+	if raw := os.Getenv("PRODUCT_CLIENT_TIMEOUT"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil {
+			cfg.Timeout = d
+		}
+	}
+	return cfg
+}
+
+// resilientClient wraps an inner Client with a circuit breaker and retries,
+// and reports outcomes as OTel metrics and span attributes.
+type resilientClient struct {
+	inner   Client
+	breaker *gobreaker.CircuitBreaker
+	timeout time.Duration
+
+	requestsTotal metric.Int64Counter
+}
+
+// New builds the resilient Client described by cfg, selecting the REST or
+// gRPC transport per cfg.Protocol, and registers its OTel instruments on
+// meter.
+func New(cfg Config, meter metric.Meter) (Client, error) {
+	var inner Client
+	switch cfg.Protocol {
+	case "grpc":
+		c, err := newGRPCClient(cfg.Addr)
+		if err != nil {
+			return nil, err
+		}
+		inner = c
+	default:
+		inner = newRESTClient(cfg.BaseURL)
+	}
+
+	requestsTotal, _ := meter.Int64Counter("product_client_requests_total",
+		metric.WithDescription("Product service calls by outcome"))
+
+	rc := &resilientClient{
+		inner:         inner,
+		timeout:       cfg.Timeout,
+		requestsTotal: requestsTotal,
+	}
+
+	rc.breaker = gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:    "product_service",
+		Timeout: 30 * time.Second,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= 5
+		},
+		// A 404 means the product service is healthy and answered
+		// correctly; only count outages (timeouts, 5xx, transport errors)
+		// against the breaker.
+		IsSuccessful: func(err error) bool {
+			return err == nil || errors.Is(err, ErrNotFound)
+		},
+	})
+
+	breakerState, _ := meter.Int64ObservableGauge("product_client_breaker_state",
+		metric.WithDescription("Circuit breaker state for the product service client: 0=closed, 1=half_open, 2=open"))
+	if _, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(breakerState, breakerStateValue(rc.breaker.State()))
+		return nil
+	}, breakerState); err != nil {
+		return nil, err
+	}
+
+	return rc, nil
+}
+
+func breakerStateValue(s gobreaker.State) int64 {
+	switch s {
+	case gobreaker.StateHalfOpen:
+		return stateHalfOpen
+	case gobreaker.StateOpen:
+		return stateOpen
+	default:
+		return stateClosed
+	}
+}
+
+func (c *resilientClient) GetProduct(ctx context.Context, productID int) (*Product, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("product_client.breaker_state", c.breaker.State().String()))
+
+	result, err := c.breaker.Execute(func() (interface{}, error) {
+		return c.callWithRetry(ctx, productID)
+	})
+
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+		if err == gobreaker.ErrOpenState || err == gobreaker.ErrTooManyRequests {
+			outcome = "breaker_open"
+		}
+	}
+	c.requestsTotal.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return result.(*Product), nil
+}
+
+// callWithRetry makes up to maxAttempts attempts against the inner client,
+// each bounded by c.timeout, backing off exponentially with jitter between
+// attempts. It stops early if ctx is done.
+func (c *resilientClient) callWithRetry(ctx context.Context, productID int) (*Product, error) {
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			if err := sleepWithJitter(ctx, attempt); err != nil {
+				return nil, lastErr
+			}
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		product, err := c.inner.GetProduct(attemptCtx, productID)
+		cancel()
+		if err == nil {
+			return product, nil
+		}
+		lastErr = err
+
+		// A 404/NotFound is the product service correctly answering "no
+		// such product" - retrying it wastes three attempts to get the
+		// same answer, so return it immediately instead.
+		if errors.Is(err, ErrNotFound) {
+			return nil, lastErr
+		}
+		if ctx.Err() != nil {
+			return nil, lastErr
+		}
+	}
+	return nil, lastErr
+}
+
+// sleepWithJitter waits out the backoff for the given attempt (1-indexed
+// retry count), or returns ctx.Err() if ctx is done first.
+func sleepWithJitter(ctx context.Context, attempt int) error {
+	base := time.Duration(1<<uint(attempt-1)) * 50 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base) + 1))
+	select {
+	case <-time.After(base + jitter):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
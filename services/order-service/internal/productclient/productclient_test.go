@@ -0,0 +1,140 @@
+package productclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// fakeClient is a Client whose GetProduct answers with a scripted sequence
+// of errors before finally succeeding, so callWithRetry's retry and
+// early-exit behavior can be tested without a real product service.
+type fakeClient struct {
+	errs    []error
+	product *Product
+	calls   int
+}
+
+func (f *fakeClient) GetProduct(ctx context.Context, productID int) (*Product, error) {
+	f.calls++
+	if len(f.errs) >= f.calls {
+		if err := f.errs[f.calls-1]; err != nil {
+			return nil, err
+		}
+	}
+	return f.product, nil
+}
+
+func newTestResilientClient(inner Client) *resilientClient {
+	meter := noop.NewMeterProvider().Meter("test")
+	requestsTotal, _ := meter.Int64Counter("product_client_requests_total_test")
+	rc := &resilientClient{
+		inner:         inner,
+		timeout:       time.Second,
+		requestsTotal: requestsTotal,
+	}
+	rc.breaker = gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name: "product_service_test",
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= 5
+		},
+		IsSuccessful: func(err error) bool {
+			return err == nil || errors.Is(err, ErrNotFound)
+		},
+	})
+	return rc
+}
+
+func TestCallWithRetry(t *testing.T) {
+	errTransient := errors.New("connection refused")
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantErr      error
+		wantAttempts int
+	}{
+		{
+			name:         "succeeds first attempt",
+			errs:         []error{nil},
+			wantErr:      nil,
+			wantAttempts: 1,
+		},
+		{
+			name:         "retries transient errors up to maxAttempts",
+			errs:         []error{errTransient, errTransient, errTransient},
+			wantErr:      errTransient,
+			wantAttempts: maxAttempts,
+		},
+		{
+			name:         "recovers after a transient error",
+			errs:         []error{errTransient, nil},
+			wantErr:      nil,
+			wantAttempts: 2,
+		},
+		{
+			name:         "does not retry ErrNotFound",
+			errs:         []error{ErrNotFound},
+			wantErr:      ErrNotFound,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeClient{errs: tt.errs, product: &Product{ID: 1}}
+			rc := newTestResilientClient(fake)
+
+			_, err := rc.callWithRetry(context.Background(), 1)
+
+			if !errors.Is(err, tt.wantErr) && err != tt.wantErr {
+				t.Fatalf("callWithRetry() error = %v, want %v", err, tt.wantErr)
+			}
+			if fake.calls != tt.wantAttempts {
+				t.Fatalf("callWithRetry() made %d attempts, want %d", fake.calls, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestGetProductDoesNotTripBreakerOnNotFound(t *testing.T) {
+	fake := &fakeClient{product: &Product{ID: 1}}
+	fake.errs = make([]error, 0, 10)
+	for i := 0; i < 10; i++ {
+		fake.errs = append(fake.errs, ErrNotFound)
+	}
+	rc := newTestResilientClient(fake)
+
+	for i := 0; i < 10; i++ {
+		if _, err := rc.GetProduct(context.Background(), 1); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("GetProduct() call %d: error = %v, want ErrNotFound", i, err)
+		}
+	}
+
+	if state := rc.breaker.State(); state != gobreaker.StateClosed {
+		t.Fatalf("breaker state = %v after repeated ErrNotFound, want StateClosed", state)
+	}
+}
+
+func TestGetProductTripsBreakerOnConsecutiveOutages(t *testing.T) {
+	errOutage := errors.New("service unavailable")
+	fake := &fakeClient{product: &Product{ID: 1}}
+	fake.errs = make([]error, 0, maxAttempts*5)
+	for i := 0; i < maxAttempts*5; i++ {
+		fake.errs = append(fake.errs, errOutage)
+	}
+	rc := newTestResilientClient(fake)
+
+	var lastErr error
+	for i := 0; i < 6; i++ {
+		_, lastErr = rc.GetProduct(context.Background(), 1)
+	}
+
+	if !errors.Is(lastErr, gobreaker.ErrOpenState) {
+		t.Fatalf("GetProduct() error after 5 consecutive outages = %v, want ErrOpenState", lastErr)
+	}
+}
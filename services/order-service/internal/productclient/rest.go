@@ -0,0 +1,66 @@
+package productclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// restClient is the original HTTP transport to the product service.
+type restClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newRESTClient(baseURL string) *restClient {
+	return &restClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+	}
+}
+
+func (c *restClient) GetProduct(ctx context.Context, productID int) (*Product, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("product.id", productID))
+
+	url := fmt.Sprintf("%s/products/%d", c.baseURL, productID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+		return nil, fmt.Errorf("product service returned status %d", resp.StatusCode)
+	}
+
+	var product Product
+	if err := json.NewDecoder(resp.Body).Decode(&product); err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("product.name", product.Name),
+		attribute.Float64("product.price", product.Price),
+		attribute.Int("product.stock", product.StockQuantity),
+	)
+
+	return &product, nil
+}
@@ -0,0 +1,158 @@
+package saga
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+)
+
+// fakeState is the in-memory backing store for the fake driver: just enough
+// of orders.status and saga_progress to exercise Coordinator's SQL without a
+// real Postgres connection.
+type fakeState struct {
+	mu          sync.Mutex
+	orderStatus map[string]string
+	progress    map[string][2]bool // [stock_reserved, payment_completed]
+	outboxTypes []string
+}
+
+func newFakeState(orders map[string]string) *fakeState {
+	return &fakeState{orderStatus: orders, progress: map[string][2]bool{}}
+}
+
+func (s *fakeState) exec(query string, args []driver.Value) (driver.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.Contains(query, "UPDATE orders"):
+		newStatus := args[0].(string)
+		orderID := args[1].(string)
+		var expected []string
+		if strings.Contains(query, "status IN") {
+			expected = []string{args[2].(string), args[3].(string)}
+		} else {
+			expected = []string{args[2].(string)}
+		}
+		current, ok := s.orderStatus[orderID]
+		if !ok {
+			return fakeResult{}, nil
+		}
+		for _, e := range expected {
+			if current == e {
+				s.orderStatus[orderID] = newStatus
+				return fakeResult{rows: 1}, nil
+			}
+		}
+		return fakeResult{}, nil
+
+	case strings.Contains(query, "DELETE FROM saga_progress"):
+		orderID := args[0].(string)
+		delete(s.progress, orderID)
+		return fakeResult{rows: 1}, nil
+
+	case strings.Contains(query, "INSERT INTO outbox"):
+		eventType := args[2].(string)
+		s.outboxTypes = append(s.outboxTypes, eventType)
+		return fakeResult{rows: 1}, nil
+	}
+
+	return nil, errors.New("fakeState.exec: unrecognized query: " + query)
+}
+
+func (s *fakeState) query(query string, args []driver.Value) (driver.Rows, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.Contains(query, "INSERT INTO saga_progress") {
+		orderID := args[0].(string)
+		reserved := args[1].(bool)
+		paid := args[2].(bool)
+		cur := s.progress[orderID]
+		cur[0] = cur[0] || reserved
+		cur[1] = cur[1] || paid
+		s.progress[orderID] = cur
+		return &fakeRows{cols: []string{"stock_reserved", "payment_completed"}, row: []driver.Value{cur[0], cur[1]}}, nil
+	}
+
+	return nil, errors.New("fakeState.query: unrecognized query: " + query)
+}
+
+// fakeResult implements driver.Result.
+type fakeResult struct{ rows int64 }
+
+func (r fakeResult) LastInsertId() (int64, error) { return 0, nil }
+func (r fakeResult) RowsAffected() (int64, error) { return r.rows, nil }
+
+// fakeRows implements driver.Rows for a single-row result, which is all
+// QueryRowContext ever needs here.
+type fakeRows struct {
+	cols   []string
+	row    []driver.Value
+	served bool
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.served {
+		return io.EOF
+	}
+	r.served = true
+	copy(dest, r.row)
+	return nil
+}
+
+// fakeDriver / fakeConn adapt fakeState to database/sql, so a *sql.DB backed
+// by it can be handed to a Coordinator exactly like the real thing.
+type fakeDriver struct{ state *fakeState }
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{state: d.state}, nil
+}
+
+type fakeConn struct{ state *fakeState }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fakeConn: Prepare not supported, use ExecContext/QueryContext")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeDriverTx{}, nil }
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return fakeDriverTx{}, nil
+}
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.state.exec(query, namedValues(args))
+}
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.state.query(query, namedValues(args))
+}
+
+func namedValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	return values
+}
+
+type fakeDriverTx struct{}
+
+func (fakeDriverTx) Commit() error   { return nil }
+func (fakeDriverTx) Rollback() error { return nil }
+
+// newFakeDB registers a fresh driver under name and opens a *sql.DB backed
+// by state. name must be unique per test (e.g. t.Name()) since database/sql
+// panics on a duplicate driver registration.
+func newFakeDB(name string, state *fakeState) *sql.DB {
+	sql.Register(name, &fakeDriver{state: state})
+	db, err := sql.Open(name, "")
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
@@ -0,0 +1,214 @@
+// Package saga implements the order lifecycle saga: it consumes replies
+// from the inventory and payment services and drives an order through
+// pending -> reserved -> confirmed, or compensates it to compensated if a
+// step is rejected.
+package saga
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"order-service/internal/events"
+	"order-service/internal/messagebus"
+	"order-service/internal/outbox"
+)
+
+// Status values an order can be driven through by the saga. These extend
+// the existing free-form order.status column with saga-specific states.
+const (
+	StatusPending     = "pending"
+	StatusReserved    = "reserved"
+	StatusConfirmed   = "confirmed"
+	StatusCompensated = "compensated"
+)
+
+// CreateTableSQL creates the saga_progress table if it does not already
+// exist. NATS gives no cross-subject ordering guarantee, so
+// events.TypePaymentCompleted can be delivered before events.TypeStockReserved for
+// the same order; this table lets the coordinator record each precondition
+// independently and confirm the order the moment both are true, whichever
+// one arrives second.
+const CreateTableSQL = `
+CREATE TABLE IF NOT EXISTS saga_progress (
+	order_id VARCHAR(36) PRIMARY KEY,
+	stock_reserved BOOLEAN NOT NULL DEFAULT FALSE,
+	payment_completed BOOLEAN NOT NULL DEFAULT FALSE,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);`
+
+// Coordinator drives the saga state machine off events.TypeStockReserved,
+// events.TypeStockReservationFailed and events.TypePaymentCompleted messages.
+type Coordinator struct {
+	db  *sql.DB
+	bus messagebus.Subscriber
+}
+
+// NewCoordinator returns a Coordinator that reads order state from db and
+// listens for saga replies on bus.
+func NewCoordinator(db *sql.DB, bus messagebus.Subscriber) *Coordinator {
+	return &Coordinator{db: db, bus: bus}
+}
+
+// Start subscribes to the reply subjects. It returns once all subscriptions
+// are registered; consumption continues on background goroutines until the
+// bus is closed. ctx is the parent context of every handler call; cancelling
+// it does not unsubscribe.
+func (c *Coordinator) Start(ctx context.Context) error {
+	if _, err := c.bus.Subscribe(ctx, "orders.StockReserved", c.handleStockReserved); err != nil {
+		return err
+	}
+	if _, err := c.bus.Subscribe(ctx, "orders.StockReservationFailed", c.handleStockReservationFailed); err != nil {
+		return err
+	}
+	if _, err := c.bus.Subscribe(ctx, "orders.PaymentCompleted", c.handlePaymentCompleted); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Coordinator) handleStockReserved(ctx context.Context, data []byte) error {
+	var env events.Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return err
+	}
+	var payload events.StockReservedPayload
+	if err := decodePayload(env.Payload, &payload); err != nil {
+		return err
+	}
+	if err := c.transition(ctx, payload.OrderID, StatusPending, StatusReserved); err != nil {
+		return err
+	}
+	return c.markProgressAndMaybeConfirm(ctx, payload.OrderID, true, false)
+}
+
+func (c *Coordinator) handleStockReservationFailed(ctx context.Context, data []byte) error {
+	var env events.Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return err
+	}
+	var payload events.StockReservationFailedPayload
+	if err := decodePayload(env.Payload, &payload); err != nil {
+		return err
+	}
+	return c.compensate(ctx, payload.OrderID, payload.Reason)
+}
+
+func (c *Coordinator) handlePaymentCompleted(ctx context.Context, data []byte) error {
+	var env events.Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return err
+	}
+	var payload events.PaymentCompletedPayload
+	if err := decodePayload(env.Payload, &payload); err != nil {
+		return err
+	}
+	return c.markProgressAndMaybeConfirm(ctx, payload.OrderID, false, true)
+}
+
+// transition moves orderID from expectedStatus to newStatus. It is a no-op
+// (not an error) if the order is no longer in expectedStatus, since replies
+// can be redelivered at-least-once.
+func (c *Coordinator) transition(ctx context.Context, orderID, expectedStatus, newStatus string) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	const updateSQL = `UPDATE orders SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3`
+	if _, err := tx.ExecContext(ctx, updateSQL, newStatus, orderID, expectedStatus); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// markProgressAndMaybeConfirm records that stockReserved and/or
+// paymentCompleted just became true for orderID and, if both preconditions
+// are now met, confirms the order in the same transaction. Recording each
+// precondition independently, rather than confirming straight off whichever
+// event arrives second, is what makes confirmation order-independent:
+// handleStockReserved and handlePaymentCompleted can call this in either
+// order, or have their calls redelivered, and the order is confirmed
+// exactly once, the moment the second precondition lands.
+func (c *Coordinator) markProgressAndMaybeConfirm(ctx context.Context, orderID string, stockReserved, paymentCompleted bool) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	const upsertSQL = `
+		INSERT INTO saga_progress (order_id, stock_reserved, payment_completed)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (order_id) DO UPDATE SET
+			stock_reserved = saga_progress.stock_reserved OR EXCLUDED.stock_reserved,
+			payment_completed = saga_progress.payment_completed OR EXCLUDED.payment_completed,
+			updated_at = NOW()
+		RETURNING stock_reserved, payment_completed`
+
+	var reserved, paid bool
+	if err := tx.QueryRowContext(ctx, upsertSQL, orderID, stockReserved, paymentCompleted).Scan(&reserved, &paid); err != nil {
+		return err
+	}
+	if !reserved || !paid {
+		return tx.Commit()
+	}
+
+	const updateSQL = `UPDATE orders SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3`
+	res, err := tx.ExecContext(ctx, updateSQL, StatusConfirmed, orderID, StatusReserved)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return tx.Commit()
+	}
+
+	if err := outbox.Write(ctx, tx, orderID, events.TypeOrderConfirmed, 3, events.OrderConfirmedPayload{OrderID: orderID}); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM saga_progress WHERE order_id = $1`, orderID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// compensate moves orderID to compensated from either pending or reserved
+// and emits OrderFailed so downstream consumers (and clients polling
+// getOrder) learn the reservation could not be satisfied.
+func (c *Coordinator) compensate(ctx context.Context, orderID, reason string) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	const updateSQL = `UPDATE orders SET status = $1, updated_at = NOW() WHERE id = $2 AND status IN ($3, $4)`
+	res, err := tx.ExecContext(ctx, updateSQL, StatusCompensated, orderID, StatusPending, StatusReserved)
+	if err != nil {
+		return err
+	}
+	if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+		return tx.Commit()
+	}
+
+	if err := outbox.Write(ctx, tx, orderID, events.TypeOrderFailed, 3, events.OrderFailedPayload{OrderID: orderID, Reason: reason}); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM saga_progress WHERE order_id = $1`, orderID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func decodePayload(raw interface{}, out interface{}) error {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,143 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"order-service/internal/events"
+)
+
+func envelopeFor(t *testing.T, payload interface{}) []byte {
+	t.Helper()
+	data, err := json.Marshal(events.Envelope{Payload: payload})
+	if err != nil {
+		t.Fatalf("marshal envelope: %v", err)
+	}
+	return data
+}
+
+func TestConfirmIsOrderIndependent(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, c *Coordinator, orderID string)
+	}{
+		{
+			name: "StockReserved then PaymentCompleted",
+			run: func(t *testing.T, c *Coordinator, orderID string) {
+				mustHandle(t, c.handleStockReserved(context.Background(), envelopeFor(t, events.StockReservedPayload{OrderID: orderID})))
+				mustHandle(t, c.handlePaymentCompleted(context.Background(), envelopeFor(t, events.PaymentCompletedPayload{OrderID: orderID})))
+			},
+		},
+		{
+			name: "PaymentCompleted before StockReserved",
+			run: func(t *testing.T, c *Coordinator, orderID string) {
+				mustHandle(t, c.handlePaymentCompleted(context.Background(), envelopeFor(t, events.PaymentCompletedPayload{OrderID: orderID})))
+				mustHandle(t, c.handleStockReserved(context.Background(), envelopeFor(t, events.StockReservedPayload{OrderID: orderID})))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderID := "order-1"
+			state := newFakeState(map[string]string{orderID: StatusPending})
+			db := newFakeDB(t.Name(), state)
+			c := NewCoordinator(db, nil)
+
+			tt.run(t, c, orderID)
+
+			if got := state.orderStatus[orderID]; got != StatusConfirmed {
+				t.Fatalf("order status = %q, want %q", got, StatusConfirmed)
+			}
+			if got := countOccurrences(state.outboxTypes, events.TypeOrderConfirmed); got != 1 {
+				t.Fatalf("OrderConfirmed emitted %d times, want exactly 1 (got %v)", got, state.outboxTypes)
+			}
+			if _, stillTracked := state.progress[orderID]; stillTracked {
+				t.Fatalf("saga_progress row for %q was not cleaned up after confirm", orderID)
+			}
+		})
+	}
+}
+
+func TestPaymentCompletedAloneDoesNotConfirm(t *testing.T) {
+	orderID := "order-1"
+	state := newFakeState(map[string]string{orderID: StatusPending})
+	db := newFakeDB(t.Name(), state)
+	c := NewCoordinator(db, nil)
+
+	mustHandle(t, c.handlePaymentCompleted(context.Background(), envelopeFor(t, events.PaymentCompletedPayload{OrderID: orderID})))
+
+	if got := state.orderStatus[orderID]; got != StatusPending {
+		t.Fatalf("order status = %q, want %q (should still be awaiting StockReserved)", got, StatusPending)
+	}
+	if len(state.outboxTypes) != 0 {
+		t.Fatalf("expected no outbox events yet, got %v", state.outboxTypes)
+	}
+}
+
+func TestHandleStockReservedIsRedeliverySafe(t *testing.T) {
+	orderID := "order-1"
+	state := newFakeState(map[string]string{orderID: StatusPending})
+	db := newFakeDB(t.Name(), state)
+	c := NewCoordinator(db, nil)
+
+	env := envelopeFor(t, events.StockReservedPayload{OrderID: orderID})
+	mustHandle(t, c.handleStockReserved(context.Background(), env))
+	mustHandle(t, c.handleStockReserved(context.Background(), env))
+
+	if got := state.orderStatus[orderID]; got != StatusReserved {
+		t.Fatalf("order status = %q, want %q", got, StatusReserved)
+	}
+}
+
+func TestCompensateFromReserved(t *testing.T) {
+	orderID := "order-1"
+	state := newFakeState(map[string]string{orderID: StatusReserved})
+	db := newFakeDB(t.Name(), state)
+	c := NewCoordinator(db, nil)
+
+	env := envelopeFor(t, events.StockReservationFailedPayload{OrderID: orderID, Reason: "out of stock"})
+	mustHandle(t, c.handleStockReservationFailed(context.Background(), env))
+
+	if got := state.orderStatus[orderID]; got != StatusCompensated {
+		t.Fatalf("order status = %q, want %q", got, StatusCompensated)
+	}
+	if got := countOccurrences(state.outboxTypes, events.TypeOrderFailed); got != 1 {
+		t.Fatalf("OrderFailed emitted %d times, want exactly 1 (got %v)", got, state.outboxTypes)
+	}
+}
+
+func TestCompensateIsNoOpOnceConfirmed(t *testing.T) {
+	orderID := "order-1"
+	state := newFakeState(map[string]string{orderID: StatusConfirmed})
+	db := newFakeDB(t.Name(), state)
+	c := NewCoordinator(db, nil)
+
+	env := envelopeFor(t, events.StockReservationFailedPayload{OrderID: orderID, Reason: "late failure"})
+	mustHandle(t, c.handleStockReservationFailed(context.Background(), env))
+
+	if got := state.orderStatus[orderID]; got != StatusConfirmed {
+		t.Fatalf("order status = %q, want unchanged %q", got, StatusConfirmed)
+	}
+	if len(state.outboxTypes) != 0 {
+		t.Fatalf("expected no outbox events for a compensate that arrived after confirmation, got %v", state.outboxTypes)
+	}
+}
+
+func mustHandle(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+}
+
+func countOccurrences(haystack []string, want string) int {
+	n := 0
+	for _, s := range haystack {
+		if s == want {
+			n++
+		}
+	}
+	return n
+}
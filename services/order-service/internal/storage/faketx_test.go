@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+// fakePingDriver backs a *bun.DB whose only interesting behavior is whether
+// PingContext succeeds, which is all Repository.reader's replica
+// health-check needs.
+type fakePingDriver struct{ pingErr error }
+
+func (d *fakePingDriver) Open(name string) (driver.Conn, error) {
+	return &fakePingConn{pingErr: d.pingErr}, nil
+}
+
+type fakePingConn struct{ pingErr error }
+
+func (c *fakePingConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fakePingConn: Prepare not supported")
+}
+func (c *fakePingConn) Close() error { return nil }
+func (c *fakePingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fakePingConn: Begin not supported")
+}
+func (c *fakePingConn) Ping(ctx context.Context) error { return c.pingErr }
+
+// newFakeBunDB registers a fresh driver under name and returns a *bun.DB
+// backed by it. name must be unique per call (e.g. derived from t.Name())
+// since database/sql panics on a duplicate driver registration.
+func newFakeBunDB(name string, pingErr error) *bun.DB {
+	sql.Register(name, &fakePingDriver{pingErr: pingErr})
+	sqldb, err := sql.Open(name, "")
+	if err != nil {
+		panic(err)
+	}
+	return bun.NewDB(sqldb, pgdialect.New())
+}
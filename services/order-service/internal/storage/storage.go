@@ -0,0 +1,193 @@
+// Package storage is the order service's data layer: a bun-backed
+// OrderRepository that instruments every statement as an OTel span and
+// splits reads across replicas while keeping writes (and read-your-writes
+// queries) pinned to the primary.
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/extra/bunotel"
+)
+
+// Order is the bun model backing the `orders` table. It doubles as the API
+// representation returned from the handlers, hence the json tags.
+type Order struct {
+	ID         string    `json:"id" bun:"id,pk"`
+	UserID     string    `json:"user_id" bun:"user_id,notnull"`
+	ProductID  int       `json:"product_id" bun:"product_id,notnull"`
+	Quantity   int       `json:"quantity" bun:"quantity,notnull"`
+	TotalPrice float64   `json:"total_price" bun:"total_price,notnull"`
+	Status     string    `json:"status" bun:"status,notnull"`
+	CreatedAt  time.Time `json:"created_at" bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt  time.Time `json:"updated_at" bun:"updated_at,notnull,default:current_timestamp"`
+
+	bun.BaseModel `bun:"table:orders,alias:o"`
+}
+
+// OrderFilter narrows List to a subset of orders.
+type OrderFilter struct {
+	UserID string
+	Status string
+	Limit  int
+	Offset int
+}
+
+type forcePrimaryKey struct{}
+
+// WithForcePrimary marks ctx so reads routed through Repository use the
+// primary instead of a replica. Use it for read-your-writes right after a
+// write on the same request (replicas can lag the primary).
+func WithForcePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forcePrimaryKey{}, true)
+}
+
+func forcePrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(forcePrimaryKey{}).(bool)
+	return v
+}
+
+// Repository is the bun-backed OrderRepository. Writes always go to the
+// primary; reads go to a replica unless the context was marked with
+// WithForcePrimary or no replicas are configured.
+type Repository struct {
+	primary  *bun.DB
+	replicas []*bun.DB
+	next     atomic.Uint64
+}
+
+// Config holds the DSNs used to open the primary and its read replicas.
+type Config struct {
+	PrimaryDSN  string
+	ReplicaDSNs []string
+}
+
+// NewRepository opens the primary and replica connections and registers
+// bunotel.NewQueryHook() on each so every statement becomes a child span of
+// the incoming request span, tagged with db.statement, db.rows_affected,
+// and db.system.
+func NewRepository(cfg Config) (*Repository, error) {
+	primary, err := openBunDB(cfg.PrimaryDSN)
+	if err != nil {
+		return nil, fmt.Errorf("open primary: %w", err)
+	}
+
+	var replicas []*bun.DB
+	for _, dsn := range cfg.ReplicaDSNs {
+		replica, err := openBunDB(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("open replica: %w", err)
+		}
+		replicas = append(replicas, replica)
+	}
+
+	return &Repository{primary: primary, replicas: replicas}, nil
+}
+
+func openBunDB(dsn string) (*bun.DB, error) {
+	sqldb := sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(dsn)))
+	db := bun.NewDB(sqldb, pgdialect.New())
+	db.AddQueryHook(bunotel.NewQueryHook(
+		bunotel.WithDBName("orders"),
+	))
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// CreateSchema creates the orders table if it does not already exist.
+func (r *Repository) CreateSchema(ctx context.Context) error {
+	_, err := r.primary.NewCreateTable().Model((*Order)(nil)).IfNotExists().Exec(ctx)
+	return err
+}
+
+// Primary returns the bun.DB bound to the primary, for callers (like the
+// outbox) that need a writable handle to run within a transaction alongside
+// an order mutation.
+func (r *Repository) Primary() *bun.DB {
+	return r.primary
+}
+
+// reader returns the bun.IDB used for read queries: a round-robin replica,
+// or the primary if none are configured or the caller forced it.
+func (r *Repository) reader(ctx context.Context) bun.IDB {
+	if forcePrimary(ctx) || len(r.replicas) == 0 {
+		return r.primary
+	}
+
+	for range r.replicas {
+		idx := r.next.Add(1) % uint64(len(r.replicas))
+		replica := r.replicas[idx]
+		if err := replica.PingContext(ctx); err == nil {
+			return replica
+		}
+	}
+	// All replicas are unreachable: fail over to the primary rather than
+	// erroring out the request.
+	return r.primary
+}
+
+// CreateTx inserts order as part of tx, so callers can write an outbox
+// entry in the same transaction.
+func (r *Repository) CreateTx(ctx context.Context, tx bun.Tx, order *Order) error {
+	_, err := tx.NewInsert().Model(order).Exec(ctx)
+	return err
+}
+
+// UpdateStatusTx sets order.status as part of tx and reports whether a row
+// was actually updated.
+func (r *Repository) UpdateStatusTx(ctx context.Context, tx bun.Tx, id, status string) (bool, error) {
+	res, err := tx.NewUpdate().
+		Model((*Order)(nil)).
+		Set("status = ?", status).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// RunInTx runs fn inside a primary transaction.
+func (r *Repository) RunInTx(ctx context.Context, fn func(ctx context.Context, tx bun.Tx) error) error {
+	return r.primary.RunInTx(ctx, nil, fn)
+}
+
+// List returns orders matching f, newest first.
+func (r *Repository) List(ctx context.Context, f OrderFilter) ([]Order, error) {
+	var orders []Order
+	q := r.reader(ctx).NewSelect().Model(&orders).Order("created_at DESC")
+
+	if f.UserID != "" {
+		q = q.Where("user_id = ?", f.UserID)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if err := q.Limit(f.Limit).Offset(f.Offset).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// Get returns a single order by ID, or sql.ErrNoRows if it doesn't exist.
+func (r *Repository) Get(ctx context.Context, id string) (*Order, error) {
+	order := new(Order)
+	if err := r.reader(ctx).NewSelect().Model(order).Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
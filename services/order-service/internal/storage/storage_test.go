@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/uptrace/bun"
+)
+
+func TestForcePrimary(t *testing.T) {
+	ctx := context.Background()
+	if forcePrimary(ctx) {
+		t.Fatal("forcePrimary(ctx) = true for a plain context, want false")
+	}
+	if !forcePrimary(WithForcePrimary(ctx)) {
+		t.Fatal("forcePrimary(WithForcePrimary(ctx)) = false, want true")
+	}
+}
+
+func TestReaderUsesPrimaryWhenNoReplicas(t *testing.T) {
+	primary := newFakeBunDB(t.Name()+"-primary", nil)
+	r := &Repository{primary: primary}
+
+	if got := r.reader(context.Background()); got != primary {
+		t.Fatalf("reader() = %v, want primary", got)
+	}
+}
+
+func TestReaderUsesPrimaryWhenForced(t *testing.T) {
+	primary := newFakeBunDB(t.Name()+"-primary", nil)
+	replica := newFakeBunDB(t.Name()+"-replica", nil)
+	r := &Repository{primary: primary, replicas: []*bun.DB{replica}}
+
+	if got := r.reader(WithForcePrimary(context.Background())); got != primary {
+		t.Fatalf("reader() = %v, want primary when forced", got)
+	}
+}
+
+func TestReaderRoundRobinsHealthyReplicas(t *testing.T) {
+	primary := newFakeBunDB(t.Name()+"-primary", nil)
+	r1 := newFakeBunDB(t.Name()+"-r1", nil)
+	r2 := newFakeBunDB(t.Name()+"-r2", nil)
+	r := &Repository{primary: primary, replicas: []*bun.DB{r1, r2}}
+
+	seen := map[*bun.DB]bool{}
+	for i := 0; i < 10; i++ {
+		got := r.reader(context.Background())
+		if got == primary {
+			t.Fatalf("reader() call %d returned primary, want a healthy replica", i)
+		}
+		for _, replica := range r.replicas {
+			if got == replica {
+				seen[replica] = true
+			}
+		}
+	}
+	if len(seen) != 2 {
+		t.Fatalf("reader() visited %d distinct replicas over 10 calls, want both", len(seen))
+	}
+}
+
+func TestReaderFailsOverToPrimaryWhenAllReplicasDown(t *testing.T) {
+	primary := newFakeBunDB(t.Name()+"-primary", nil)
+	down1 := newFakeBunDB(t.Name()+"-down1", errors.New("connection refused"))
+	down2 := newFakeBunDB(t.Name()+"-down2", errors.New("connection refused"))
+	r := &Repository{primary: primary, replicas: []*bun.DB{down1, down2}}
+
+	if got := r.reader(context.Background()); got != primary {
+		t.Fatalf("reader() = %v, want primary when every replica is unreachable", got)
+	}
+}